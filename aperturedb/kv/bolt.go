@@ -0,0 +1,110 @@
+package kv
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+var bucketName = []byte("aperture-kv")
+
+// boltStore is a Store backed by a single-file BoltDB database. It has no
+// external dependencies, which makes it a good fit for edge or onion-only
+// deployments that can't reach an etcd or Redis cluster.
+type boltStore struct {
+	db *bolt.DB
+}
+
+// NewBoltStore opens (creating if necessary) a BoltDB database at path and
+// returns a Store backed by it.
+func NewBoltStore(path string) (Store, error) {
+	db, err := bolt.Open(path, 0600, nil)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open bolt db: %w", err)
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(bucketName)
+		return err
+	})
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("unable to create bucket: %w", err)
+	}
+
+	return &boltStore{db: db}, nil
+}
+
+func (b *boltStore) Get(_ context.Context, key string) ([]byte, error) {
+	var value []byte
+	err := b.db.View(func(tx *bolt.Tx) error {
+		v := tx.Bucket(bucketName).Get([]byte(key))
+		if v == nil {
+			return ErrKeyNotFound
+		}
+
+		value = append([]byte(nil), v...)
+		return nil
+	})
+
+	return value, err
+}
+
+func (b *boltStore) Put(_ context.Context, key string, value []byte) error {
+	return b.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(bucketName).Put([]byte(key), value)
+	})
+}
+
+func (b *boltStore) Delete(_ context.Context, key string) error {
+	return b.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(bucketName).Delete([]byte(key))
+	})
+}
+
+func (b *boltStore) List(_ context.Context, prefix string) ([]KeyValue, error) {
+	var out []KeyValue
+	err := b.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(bucketName).Cursor()
+		prefixBytes := []byte(prefix)
+		for k, v := c.Seek(prefixBytes); k != nil && bytes.HasPrefix(k, prefixBytes); k, v = c.Next() {
+			out = append(out, KeyValue{
+				Key:   string(k),
+				Value: append([]byte(nil), v...),
+			})
+		}
+
+		return nil
+	})
+
+	return out, err
+}
+
+func (b *boltStore) Watch(ctx context.Context, prefix string) (
+	<-chan KeyValue, error) {
+
+	return nil, fmt.Errorf("bolt kv store does not support watch, " +
+		"poll List instead")
+}
+
+func (b *boltStore) Txn(_ context.Context, txn Txn) error {
+	return b.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(bucketName)
+		current := bucket.Get([]byte(txn.Key))
+
+		switch {
+		case txn.CompareValue == nil && current != nil:
+			return ErrTxnFailed
+		case txn.CompareValue != nil && !bytes.Equal(current, txn.CompareValue):
+			return ErrTxnFailed
+		}
+
+		return bucket.Put([]byte(txn.Key), txn.NewValue)
+	})
+}
+
+func (b *boltStore) Close() error {
+	return b.db.Close()
+}
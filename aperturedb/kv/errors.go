@@ -0,0 +1,13 @@
+package kv
+
+import "errors"
+
+var (
+	// ErrKeyNotFound is returned by Get when the requested key doesn't
+	// exist.
+	ErrKeyNotFound = errors.New("kv: key not found")
+
+	// ErrTxnFailed is returned by Txn when the compare-and-swap
+	// precondition didn't hold.
+	ErrTxnFailed = errors.New("kv: transaction compare failed")
+)
@@ -0,0 +1,125 @@
+package kv
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+// etcdStore is a Store backed by etcd, the original and still the
+// recommended backend for multi-instance Aperture deployments.
+type etcdStore struct {
+	client *clientv3.Client
+}
+
+// NewEtcdStore connects to the etcd cluster at host, authenticating with
+// user/password, and returns a Store backed by it.
+func NewEtcdStore(host, user, password string) (Store, error) {
+	client, err := clientv3.New(clientv3.Config{
+		Endpoints:   []string{host},
+		Username:    user,
+		Password:    password,
+		DialTimeout: 5 * time.Second,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to connect to etcd: %w", err)
+	}
+
+	return &etcdStore{client: client}, nil
+}
+
+func (e *etcdStore) Get(ctx context.Context, key string) ([]byte, error) {
+	resp, err := e.client.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(resp.Kvs) == 0 {
+		return nil, ErrKeyNotFound
+	}
+
+	return resp.Kvs[0].Value, nil
+}
+
+func (e *etcdStore) Put(ctx context.Context, key string, value []byte) error {
+	_, err := e.client.Put(ctx, key, string(value))
+	return err
+}
+
+func (e *etcdStore) Delete(ctx context.Context, key string) error {
+	_, err := e.client.Delete(ctx, key)
+	return err
+}
+
+func (e *etcdStore) List(ctx context.Context, prefix string) ([]KeyValue, error) {
+	resp, err := e.client.Get(ctx, prefix, clientv3.WithPrefix())
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]KeyValue, len(resp.Kvs))
+	for i, kv := range resp.Kvs {
+		out[i] = KeyValue{Key: string(kv.Key), Value: kv.Value}
+	}
+
+	return out, nil
+}
+
+func (e *etcdStore) Watch(ctx context.Context, prefix string) (
+	<-chan KeyValue, error) {
+
+	ch := make(chan KeyValue)
+	watchChan := e.client.Watch(ctx, prefix, clientv3.WithPrefix())
+
+	go func() {
+		defer close(ch)
+
+		for resp := range watchChan {
+			for _, ev := range resp.Events {
+				kv := KeyValue{Key: string(ev.Kv.Key)}
+				if ev.Type == clientv3.EventTypePut {
+					kv.Value = ev.Kv.Value
+				}
+
+				select {
+				case ch <- kv:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return ch, nil
+}
+
+func (e *etcdStore) Txn(ctx context.Context, txn Txn) error {
+	var cmp clientv3.Cmp
+	if txn.CompareValue == nil {
+		cmp = clientv3.Compare(clientv3.CreateRevision(txn.Key), "=", 0)
+	} else {
+		cmp = clientv3.Compare(
+			clientv3.Value(txn.Key), "=", string(txn.CompareValue),
+		)
+	}
+
+	resp, err := e.client.Txn(ctx).
+		If(cmp).
+		Then(clientv3.OpPut(txn.Key, string(txn.NewValue))).
+		Commit()
+	if err != nil {
+		return err
+	}
+
+	if !resp.Succeeded {
+		return ErrTxnFailed
+	}
+
+	return nil
+}
+
+func (e *etcdStore) Close() error {
+	return e.client.Close()
+}
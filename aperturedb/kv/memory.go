@@ -0,0 +1,139 @@
+package kv
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"sync"
+)
+
+// memoryStore is a Store backed by an in-memory map. It is intended for
+// tests and for ephemeral hashmail deployments that don't need their state
+// to survive a restart.
+type memoryStore struct {
+	mu       sync.Mutex
+	data     map[string][]byte
+	watchers map[string][]chan KeyValue
+}
+
+// NewMemoryStore returns a Store backed by an in-memory map.
+func NewMemoryStore() Store {
+	return &memoryStore{
+		data:     make(map[string][]byte),
+		watchers: make(map[string][]chan KeyValue),
+	}
+}
+
+func (m *memoryStore) Get(_ context.Context, key string) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	val, ok := m.data[key]
+	if !ok {
+		return nil, ErrKeyNotFound
+	}
+
+	return val, nil
+}
+
+func (m *memoryStore) Put(_ context.Context, key string, value []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.data[key] = value
+	m.notify(key, value)
+
+	return nil
+}
+
+func (m *memoryStore) Delete(_ context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.data, key)
+	m.notify(key, nil)
+
+	return nil
+}
+
+func (m *memoryStore) List(_ context.Context, prefix string) ([]KeyValue, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var out []KeyValue
+	for k, v := range m.data {
+		if strings.HasPrefix(k, prefix) {
+			out = append(out, KeyValue{Key: k, Value: v})
+		}
+	}
+
+	return out, nil
+}
+
+func (m *memoryStore) Watch(ctx context.Context, prefix string) (
+	<-chan KeyValue, error) {
+
+	ch := make(chan KeyValue, 1)
+
+	m.mu.Lock()
+	m.watchers[prefix] = append(m.watchers[prefix], ch)
+	m.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+
+		m.mu.Lock()
+		defer m.mu.Unlock()
+
+		watchers := m.watchers[prefix]
+		for i, w := range watchers {
+			if w == ch {
+				m.watchers[prefix] = append(
+					watchers[:i], watchers[i+1:]...,
+				)
+				break
+			}
+		}
+		close(ch)
+	}()
+
+	return ch, nil
+}
+
+func (m *memoryStore) Txn(_ context.Context, txn Txn) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	current, exists := m.data[txn.Key]
+	switch {
+	case txn.CompareValue == nil && exists:
+		return ErrTxnFailed
+	case txn.CompareValue != nil && !bytes.Equal(current, txn.CompareValue):
+		return ErrTxnFailed
+	}
+
+	m.data[txn.Key] = txn.NewValue
+	m.notify(txn.Key, txn.NewValue)
+
+	return nil
+}
+
+func (m *memoryStore) Close() error {
+	return nil
+}
+
+// notify must be called with m.mu held.
+func (m *memoryStore) notify(key string, value []byte) {
+	for prefix, watchers := range m.watchers {
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+
+		for _, ch := range watchers {
+			select {
+			case ch <- KeyValue{Key: key, Value: value}:
+			default:
+			}
+		}
+	}
+}
@@ -0,0 +1,148 @@
+package kv
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// casScript atomically compares the value stored at KEYS[1] against
+// ARGV[1] (ignored when ARGV[2] is "1", which instead requires the key to
+// not exist) and, if the comparison holds, sets it to ARGV[3]. Running the
+// compare-and-swap as a single Lua script makes it atomic with respect to
+// every other client, which a separate GET followed by SET is not.
+var casScript = redis.NewScript(`
+local exists = redis.call('EXISTS', KEYS[1])
+if ARGV[2] == '1' then
+	if exists == 1 then
+		return 0
+	end
+else
+	if exists == 0 then
+		return 0
+	end
+	if redis.call('GET', KEYS[1]) ~= ARGV[1] then
+		return 0
+	end
+end
+redis.call('SET', KEYS[1], ARGV[3])
+return 1
+`)
+
+// redisStore is a Store backed by Redis, intended for HA deployments that
+// front multiple Aperture instances.
+type redisStore struct {
+	client *redis.Client
+	db     int
+}
+
+// NewRedisStore connects to the Redis instance at addr and returns a Store
+// backed by it.
+func NewRedisStore(addr, password string, db int) (Store, error) {
+	client := redis.NewClient(&redis.Options{
+		Addr:     addr,
+		Password: password,
+		DB:       db,
+	})
+
+	if err := client.Ping(context.Background()).Err(); err != nil {
+		return nil, fmt.Errorf("unable to connect to redis: %w", err)
+	}
+
+	return &redisStore{client: client, db: db}, nil
+}
+
+// keyspaceChannel returns the PSUBSCRIBE pattern matching the
+// keyspace-notification channels Redis publishes changes to keys under
+// prefix in db on.
+func (r *redisStore) keyspaceChannel(prefix string) string {
+	return fmt.Sprintf("__keyspace@%d__:%s*", r.db, prefix)
+}
+
+func (r *redisStore) Get(ctx context.Context, key string) ([]byte, error) {
+	val, err := r.client.Get(ctx, key).Bytes()
+	if err == redis.Nil {
+		return nil, ErrKeyNotFound
+	}
+
+	return val, err
+}
+
+func (r *redisStore) Put(ctx context.Context, key string, value []byte) error {
+	return r.client.Set(ctx, key, value, 0).Err()
+}
+
+func (r *redisStore) Delete(ctx context.Context, key string) error {
+	return r.client.Del(ctx, key).Err()
+}
+
+func (r *redisStore) List(ctx context.Context, prefix string) ([]KeyValue, error) {
+	var out []KeyValue
+	iter := r.client.Scan(ctx, 0, prefix+"*", 0).Iterator()
+	for iter.Next(ctx) {
+		key := iter.Val()
+		val, err := r.client.Get(ctx, key).Bytes()
+		if err != nil {
+			continue
+		}
+
+		out = append(out, KeyValue{Key: key, Value: val})
+	}
+
+	return out, iter.Err()
+}
+
+func (r *redisStore) Watch(ctx context.Context, prefix string) (
+	<-chan KeyValue, error) {
+
+	channelPrefix := fmt.Sprintf("__keyspace@%d__:", r.db)
+	pubsub := r.client.PSubscribe(ctx, r.keyspaceChannel(prefix))
+	ch := make(chan KeyValue)
+
+	go func() {
+		defer close(ch)
+		defer pubsub.Close()
+
+		for msg := range pubsub.Channel() {
+			key := msg.Channel[len(channelPrefix):]
+			val, err := r.client.Get(ctx, key).Bytes()
+			if err != nil {
+				val = nil
+			}
+
+			select {
+			case ch <- KeyValue{Key: key, Value: val}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return ch, nil
+}
+
+func (r *redisStore) Txn(ctx context.Context, txn Txn) error {
+	compareIsNil := "0"
+	if txn.CompareValue == nil {
+		compareIsNil = "1"
+	}
+
+	result, err := casScript.Run(
+		ctx, r.client, []string{txn.Key},
+		string(txn.CompareValue), compareIsNil, string(txn.NewValue),
+	).Int()
+	if err != nil {
+		return err
+	}
+
+	if result == 0 {
+		return ErrTxnFailed
+	}
+
+	return nil
+}
+
+func (r *redisStore) Close() error {
+	return r.client.Close()
+}
@@ -0,0 +1,13 @@
+package kv
+
+import "testing"
+
+func TestRedisStoreKeyspaceChannel(t *testing.T) {
+	store := &redisStore{db: 3}
+
+	got := store.keyspaceChannel("a/")
+	want := "__keyspace@3__:a/*"
+	if got != want {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+}
@@ -0,0 +1,52 @@
+// Package kv abstracts the key-value store backing the LSAT challenger and
+// hashmail mailbox state behind a single interface, so those components
+// don't need to know whether they're talking to etcd, a local BoltDB file,
+// an in-memory map, or Redis.
+package kv
+
+import "context"
+
+// KeyValue is a single key/value pair returned by List.
+type KeyValue struct {
+	Key   string
+	Value []byte
+}
+
+// Txn is a single atomic compare-and-swap style transaction: Put only
+// applies if the key's current value matches CompareValue (nil means "key
+// must not exist").
+type Txn struct {
+	Key          string
+	CompareValue []byte
+	NewValue     []byte
+}
+
+// Store is implemented by every key-value backend Aperture can use to
+// persist LSAT challenger and hashmail mailbox state.
+type Store interface {
+	// Get returns the value stored under key, or ErrKeyNotFound if it
+	// doesn't exist.
+	Get(ctx context.Context, key string) ([]byte, error)
+
+	// Put stores value under key, overwriting any previous value.
+	Put(ctx context.Context, key string, value []byte) error
+
+	// Delete removes key. It is not an error to delete a key that
+	// doesn't exist.
+	Delete(ctx context.Context, key string) error
+
+	// List returns every key/value pair whose key has the given prefix.
+	List(ctx context.Context, prefix string) ([]KeyValue, error)
+
+	// Watch streams KeyValue updates (puts and deletes, the latter with
+	// a nil Value) for keys under prefix until the context is canceled.
+	// The bolt backend does not support Watch and returns an error.
+	Watch(ctx context.Context, prefix string) (<-chan KeyValue, error)
+
+	// Txn atomically applies a compare-and-swap transaction, returning
+	// ErrTxnFailed if CompareValue didn't match the key's current value.
+	Txn(ctx context.Context, txn Txn) error
+
+	// Close releases any resources held by the store.
+	Close() error
+}
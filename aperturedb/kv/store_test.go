@@ -0,0 +1,118 @@
+package kv
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+func testStoreBasicOps(t *testing.T, store Store) {
+	t.Helper()
+
+	ctx := context.Background()
+
+	if _, err := store.Get(ctx, "missing"); !errors.Is(err, ErrKeyNotFound) {
+		t.Fatalf("expected ErrKeyNotFound, got %v", err)
+	}
+
+	if err := store.Put(ctx, "a/1", []byte("one")); err != nil {
+		t.Fatalf("put failed: %v", err)
+	}
+	if err := store.Put(ctx, "a/2", []byte("two")); err != nil {
+		t.Fatalf("put failed: %v", err)
+	}
+	if err := store.Put(ctx, "b/1", []byte("three")); err != nil {
+		t.Fatalf("put failed: %v", err)
+	}
+
+	val, err := store.Get(ctx, "a/1")
+	if err != nil {
+		t.Fatalf("get failed: %v", err)
+	}
+	if string(val) != "one" {
+		t.Fatalf("expected %q, got %q", "one", val)
+	}
+
+	list, err := store.List(ctx, "a/")
+	if err != nil {
+		t.Fatalf("list failed: %v", err)
+	}
+	if len(list) != 2 {
+		t.Fatalf("expected 2 entries under prefix a/, got %d", len(list))
+	}
+
+	if err := store.Delete(ctx, "a/1"); err != nil {
+		t.Fatalf("delete failed: %v", err)
+	}
+	if _, err := store.Get(ctx, "a/1"); !errors.Is(err, ErrKeyNotFound) {
+		t.Fatalf("expected ErrKeyNotFound after delete, got %v", err)
+	}
+}
+
+func testStoreTxn(t *testing.T, store Store) {
+	t.Helper()
+
+	ctx := context.Background()
+
+	// Creating a brand new key requires CompareValue == nil.
+	err := store.Txn(ctx, Txn{Key: "txn/1", NewValue: []byte("v1")})
+	if err != nil {
+		t.Fatalf("txn create failed: %v", err)
+	}
+
+	// Trying to "create" it again must fail.
+	err = store.Txn(ctx, Txn{Key: "txn/1", NewValue: []byte("v2")})
+	if !errors.Is(err, ErrTxnFailed) {
+		t.Fatalf("expected ErrTxnFailed, got %v", err)
+	}
+
+	// A compare-and-swap against the wrong value must fail.
+	err = store.Txn(ctx, Txn{
+		Key:          "txn/1",
+		CompareValue: []byte("wrong"),
+		NewValue:     []byte("v2"),
+	})
+	if !errors.Is(err, ErrTxnFailed) {
+		t.Fatalf("expected ErrTxnFailed, got %v", err)
+	}
+
+	// A compare-and-swap against the right value must succeed.
+	err = store.Txn(ctx, Txn{
+		Key:          "txn/1",
+		CompareValue: []byte("v1"),
+		NewValue:     []byte("v2"),
+	})
+	if err != nil {
+		t.Fatalf("txn cas failed: %v", err)
+	}
+
+	val, err := store.Get(ctx, "txn/1")
+	if err != nil {
+		t.Fatalf("get failed: %v", err)
+	}
+	if string(val) != "v2" {
+		t.Fatalf("expected %q, got %q", "v2", val)
+	}
+}
+
+func TestMemoryStore(t *testing.T) {
+	store := NewMemoryStore()
+	defer store.Close()
+
+	testStoreBasicOps(t, store)
+	testStoreTxn(t, store)
+}
+
+func TestBoltStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "kv.db")
+
+	store, err := NewBoltStore(path)
+	if err != nil {
+		t.Fatalf("unable to open bolt store: %v", err)
+	}
+	defer store.Close()
+
+	testStoreBasicOps(t, store)
+	testStoreTxn(t, store)
+}
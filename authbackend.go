@@ -0,0 +1,48 @@
+package aperture
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lightninglabs/aperture/lnbackend"
+)
+
+// NewLNBackend constructs the lnbackend.Backend selected by cfg.Backend,
+// mirroring how DatabaseBackend and KVBackend select their own concrete
+// implementations.
+func NewLNBackend(ctx context.Context, cfg *AuthConfig) (lnbackend.Backend, error) {
+	switch cfg.Backend {
+	case "", "lnd":
+		return lnbackend.NewLndBackend(lnbackend.LndConfig{
+			Host:    cfg.LndHost,
+			TLSPath: cfg.TLSPath,
+			MacDir:  cfg.MacDir,
+			Network: cfg.Network,
+		})
+
+	case "cln":
+		return lnbackend.NewClnBackend(lnbackend.ClnConfig{
+			Host:     cfg.Cln.Host,
+			RunePath: cfg.Cln.RunePath,
+			RootCert: cfg.Cln.RootCert,
+		})
+
+	case "lnc":
+		return lnbackend.NewLncBackend(ctx, lnbackend.LncConfig{
+			Pairing:   cfg.Lnc.Pairing,
+			LocalKey:  cfg.Lnc.LocalKey,
+			RemoteKey: cfg.Lnc.RemoteKey,
+		})
+
+	case "lndhub":
+		return lnbackend.NewLndHubBackend(ctx, lnbackend.LndHubConfig{
+			Address:  cfg.LndHub.Address,
+			Login:    cfg.LndHub.Login,
+			Password: cfg.LndHub.Password,
+		})
+
+	default:
+		return nil, fmt.Errorf("unknown authenticator backend %q",
+			cfg.Backend)
+	}
+}
@@ -0,0 +1,207 @@
+// genconfig generates GetX/SetX accessor methods for the Config structs
+// marked with a "+genconfig:accessors" doc comment. It's invoked via
+// go:generate in config.go and writes its output to config_accessors.go.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"log"
+	"os"
+	"sort"
+	"strings"
+)
+
+const marker = "+genconfig:accessors"
+
+// extraImports are packages referenced by generated field types that aren't
+// otherwise visible from the marker comment alone; keyed by the package
+// identifier used in field types (e.g. "time" for time.Duration), valued by
+// its full import path.
+var extraImports = map[string]string{
+	"time":       "time",
+	"aperturedb": "github.com/lightninglabs/aperture/aperturedb",
+	"proxy":      "github.com/lightninglabs/aperture/proxy",
+}
+
+type field struct {
+	name string
+	typ  string
+}
+
+type target struct {
+	name   string
+	fields []field
+}
+
+func main() {
+	input := flag.String("input", "config.go", "source file to scan for +genconfig:accessors structs")
+	output := flag.String("output", "config_accessors.go", "file to write the generated accessors to")
+	flag.Parse()
+
+	if err := run(*input, *output); err != nil {
+		log.Fatalf("genconfig: %v", err)
+	}
+}
+
+func run(input, output string) error {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, input, nil, parser.ParseComments)
+	if err != nil {
+		return fmt.Errorf("unable to parse %v: %w", input, err)
+	}
+
+	targets, err := collectTargets(fset, file)
+	if err != nil {
+		return err
+	}
+
+	src, err := render(targets)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(output, src, 0o644)
+}
+
+// collectTargets walks the top-level type declarations in file and returns
+// one target per struct type whose doc comment carries the marker.
+func collectTargets(fset *token.FileSet, file *ast.File) ([]target, error) {
+	var targets []target
+
+	for _, decl := range file.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.TYPE {
+			continue
+		}
+
+		for _, spec := range genDecl.Specs {
+			typeSpec, ok := spec.(*ast.TypeSpec)
+			if !ok {
+				continue
+			}
+
+			structType, ok := typeSpec.Type.(*ast.StructType)
+			if !ok {
+				continue
+			}
+
+			if !hasMarker(genDecl.Doc) && !hasMarker(typeSpec.Doc) {
+				continue
+			}
+
+			t, err := newTarget(fset, typeSpec.Name.Name, structType)
+			if err != nil {
+				return nil, err
+			}
+
+			targets = append(targets, t)
+		}
+	}
+
+	return targets, nil
+}
+
+func hasMarker(doc *ast.CommentGroup) bool {
+	if doc == nil {
+		return false
+	}
+
+	return strings.Contains(doc.Text(), marker)
+}
+
+func newTarget(fset *token.FileSet, name string, st *ast.StructType) (target, error) {
+	t := target{name: name}
+
+	for _, f := range st.Fields.List {
+		// Skip anonymous (embedded) fields; they have no names to
+		// build a Get/Set pair from.
+		if len(f.Names) == 0 {
+			continue
+		}
+
+		var buf bytes.Buffer
+		if err := format.Node(&buf, fset, f.Type); err != nil {
+			return target{}, fmt.Errorf("unable to render type "+
+				"of field %v on %v: %w", f.Names[0].Name,
+				name, err)
+		}
+		typ := buf.String()
+
+		for _, n := range f.Names {
+			if !n.IsExported() {
+				continue
+			}
+
+			t.fields = append(t.fields, field{
+				name: n.Name,
+				typ:  typ,
+			})
+		}
+	}
+
+	return t, nil
+}
+
+// render produces the full contents of the generated accessors file,
+// including only the imports actually used by the collected targets.
+func render(targets []target) ([]byte, error) {
+	var buf bytes.Buffer
+
+	buf.WriteString("// Code generated by go generate ./cmd/genconfig; DO NOT EDIT.\n\n")
+	buf.WriteString("package aperture\n\n")
+
+	imports := usedImports(targets)
+	if len(imports) > 0 {
+		buf.WriteString("import (\n")
+		for _, imp := range imports {
+			fmt.Fprintf(&buf, "\t%q\n", imp)
+		}
+		buf.WriteString(")\n\n")
+	}
+
+	for i, t := range targets {
+		if i > 0 {
+			buf.WriteString("\n")
+		}
+
+		recv := strings.ToLower(t.name[:1])
+		for _, f := range t.fields {
+			fmt.Fprintf(&buf, "func (%s *%s) Get%s() %s { return %s.%s }\n",
+				recv, t.name, f.name, f.typ, recv, f.name)
+			fmt.Fprintf(&buf, "func (%s *%s) Set%s(v %s) { %s.%s = v }\n",
+				recv, t.name, f.name, f.typ, recv, f.name)
+		}
+	}
+
+	return format.Source(buf.Bytes())
+}
+
+// usedImports returns the sorted import paths referenced by any field type
+// across targets, based on the package identifier prefix (e.g. "time." or
+// "aperturedb.") found in its rendered type string.
+func usedImports(targets []target) []string {
+	seen := make(map[string]bool)
+	for _, t := range targets {
+		for _, f := range t.fields {
+			for ident, path := range extraImports {
+				if strings.Contains(f.typ, ident+".") {
+					seen[path] = true
+				}
+			}
+		}
+	}
+
+	imports := make([]string, 0, len(seen))
+	for path := range seen {
+		imports = append(imports, path)
+	}
+	sort.Strings(imports)
+
+	return imports
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRunGeneratesMarkedStructsOnly(t *testing.T) {
+	src := `package fixture
+
+// +genconfig:accessors
+type Marked struct {
+	Name string
+	Port uint16
+}
+
+type Unmarked struct {
+	Secret string
+}
+`
+	dir := t.TempDir()
+	input := filepath.Join(dir, "fixture.go")
+	if err := os.WriteFile(input, []byte(src), 0o644); err != nil {
+		t.Fatalf("unable to write fixture: %v", err)
+	}
+
+	output := filepath.Join(dir, "fixture_accessors.go")
+	if err := run(input, output); err != nil {
+		t.Fatalf("run failed: %v", err)
+	}
+
+	got, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatalf("unable to read generated output: %v", err)
+	}
+
+	for _, want := range []string{
+		"func (m *Marked) GetName() string",
+		"func (m *Marked) SetName(v string)",
+		"func (m *Marked) GetPort() uint16",
+		"func (m *Marked) SetPort(v uint16)",
+	} {
+		if !strings.Contains(string(got), want) {
+			t.Fatalf("expected generated output to contain %q, got:\n%s",
+				want, got)
+		}
+	}
+
+	if strings.Contains(string(got), "Unmarked") {
+		t.Fatalf("expected generated output to skip the unmarked "+
+			"struct, got:\n%s", got)
+	}
+}
@@ -1,5 +1,7 @@
 package aperture
 
+//go:generate go run ./cmd/genconfig
+
 import (
 	"errors"
 	"fmt"
@@ -30,86 +32,201 @@ var (
 	)
 )
 
+// EtcdConfig configures the etcd key-value store backend.
+//
+// +genconfig:accessors
 type EtcdConfig struct {
-	Host     string `long:"host" description:"host:port of an active etcd instance"`
-	User     string `long:"user" description:"user authorized to access the etcd host"`
-	Password string `long:"password" description:"password of the etcd user"`
+	Host     string `long:"host" env:"APERTURE_ETCD_HOST" description:"host:port of an active etcd instance"`
+	User     string `long:"user" env:"APERTURE_ETCD_USER" description:"user authorized to access the etcd host"`
+	Password string `long:"password" env:"APERTURE_ETCD_PASSWORD" description:"password of the etcd user"`
+}
+
+// BoltConfig configures the BoltDB key-value store backend.
+type BoltConfig struct {
+	DatabaseFileName string `long:"databasefilename" env:"APERTURE_KV_BOLT_DATABASEFILENAME" description:"Path to the BoltDB database file."`
+}
+
+// RedisConfig configures the Redis key-value store backend.
+type RedisConfig struct {
+	Host     string `long:"host" env:"APERTURE_KV_REDIS_HOST" description:"host:port of an active Redis instance"`
+	Password string `long:"password" env:"APERTURE_KV_REDIS_PASSWORD" description:"password of the Redis instance, if any"`
+	DB       int    `long:"db" env:"APERTURE_KV_REDIS_DB" description:"The Redis logical database to use."`
 }
 
+// AuthConfig configures the LSAT authenticator and the Lightning backend it
+// mints and tracks invoices against.
+//
+// +genconfig:accessors
 type AuthConfig struct {
 	// LndHost is the hostname of the LND instance to connect to.
-	LndHost string `long:"lndhost" description:"Hostname of the LND instance to connect to"`
+	LndHost string `long:"lndhost" env:"APERTURE_AUTHENTICATOR_LNDHOST" description:"Hostname of the LND instance to connect to"`
+
+	TLSPath string `long:"tlspath" env:"APERTURE_AUTHENTICATOR_TLSPATH" description:"Path to LND instance's tls certificate"`
+
+	MacDir string `long:"macdir" env:"APERTURE_AUTHENTICATOR_MACDIR" description:"Directory containing LND instance's macaroons"`
+
+	Network string `long:"network" env:"APERTURE_AUTHENTICATOR_NETWORK" description:"The network LND is connected to." choice:"regtest" choice:"simnet" choice:"testnet" choice:"mainnet"`
+
+	Disable bool `long:"disable" env:"APERTURE_AUTHENTICATOR_DISABLE" description:"Whether to disable the LSAT authenticator, whichever backend it uses."`
 
-	TLSPath string `long:"tlspath" description:"Path to LND instance's tls certificate"`
+	// Backend selects which Lightning backend the authenticator mints
+	// and tracks LSAT invoices against. Defaults to "lnd" so existing
+	// configs keep working unchanged.
+	Backend string `long:"backend" env:"APERTURE_AUTHENTICATOR_BACKEND" description:"The Lightning backend used to mint and track invoices." choice:"lnd" choice:"cln" choice:"lnc" choice:"lndhub"`
 
-	MacDir string `long:"macdir" description:"Directory containing LND instance's macaroons"`
+	// Cln is the configuration used when Backend is "cln".
+	Cln *ClnAuthConfig `group:"cln" namespace:"cln"`
 
-	Network string `long:"network" description:"The network LND is connected to." choice:"regtest" choice:"simnet" choice:"testnet" choice:"mainnet"`
+	// Lnc is the configuration used when Backend is "lnc".
+	Lnc *LncAuthConfig `group:"lnc" namespace:"lnc"`
 
-	Disable bool `long:"disable" description:"Whether to disable LND auth."`
+	// LndHub is the configuration used when Backend is "lndhub".
+	LndHub *LndHubAuthConfig `group:"lndhub" namespace:"lndhub"`
 }
 
-func (a *AuthConfig) validate() error {
-	// If we're disabled, we don't mind what these values are.
+// ClnAuthConfig holds the connection details for a Core Lightning backend,
+// reached over its gRPC/rune interface.
+type ClnAuthConfig struct {
+	Host     string `long:"host" env:"APERTURE_AUTHENTICATOR_CLN_HOST" description:"host:port of the CLN gRPC interface."`
+	RunePath string `long:"runepath" env:"APERTURE_AUTHENTICATOR_CLN_RUNEPATH" description:"Path to the rune used to authenticate with CLN."`
+	RootCert string `long:"rootcert" env:"APERTURE_AUTHENTICATOR_CLN_ROOTCERT" description:"Path to the CLN gRPC root certificate."`
+}
+
+// LncAuthConfig holds the pairing details used to reach a remote node
+// through Lightning Node Connect over the hashmail transport.
+type LncAuthConfig struct {
+	Pairing   string `long:"pairing" env:"APERTURE_AUTHENTICATOR_LNC_PAIRING" description:"The LNC pairing phrase used to bootstrap the session."`
+	LocalKey  string `long:"localkey" env:"APERTURE_AUTHENTICATOR_LNC_LOCALKEY" description:"The local static key used for the LNC noise handshake."`
+	RemoteKey string `long:"remotekey" env:"APERTURE_AUTHENTICATOR_LNC_REMOTEKEY" description:"The remote node's static key, once known."`
+}
+
+// LndHubAuthConfig holds the connection details for an LNDHub-compatible
+// HTTP endpoint.
+type LndHubAuthConfig struct {
+	Address  string `long:"address" env:"APERTURE_AUTHENTICATOR_LNDHUB_ADDRESS" description:"Base URL of the LNDHub-compatible endpoint."`
+	Login    string `long:"login" env:"APERTURE_AUTHENTICATOR_LNDHUB_LOGIN" description:"Login used to authenticate against the LNDHub endpoint."`
+	Password string `long:"password" env:"APERTURE_AUTHENTICATOR_LNDHUB_PASSWORD" description:"Password used to authenticate against the LNDHub endpoint."`
+}
+
+// DefaultMode returns the auth mode that services without an explicit Auth
+// override should use.
+func (a *AuthConfig) DefaultMode() proxy.AuthMode {
 	if a.Disable {
-		return nil
+		return proxy.AuthNone
 	}
 
-	if a.LndHost == "" {
-		return errors.New("lnd host required")
-	}
+	return proxy.AuthLSAT
+}
 
-	if a.TLSPath == "" {
-		return errors.New("lnd tls required")
+func (a *AuthConfig) validate() error {
+	// If we're disabled, we don't mind what these values are.
+	if a.Disable {
+		return nil
 	}
 
-	if a.MacDir == "" {
-		return errors.New("lnd mac dir required")
+	switch a.Backend {
+	case "", "lnd":
+		if a.LndHost == "" {
+			return errors.New("lnd host required")
+		}
+
+		if a.TLSPath == "" {
+			return errors.New("lnd tls required")
+		}
+
+		if a.MacDir == "" {
+			return errors.New("lnd mac dir required")
+		}
+
+	case "cln":
+		if a.Cln.Host == "" {
+			return errors.New("cln host required")
+		}
+
+		if a.Cln.RunePath == "" {
+			return errors.New("cln rune path required")
+		}
+
+		if a.Cln.RootCert == "" {
+			return errors.New("cln root cert required")
+		}
+
+	case "lnc":
+		if a.Lnc.Pairing == "" {
+			return errors.New("lnc pairing phrase required")
+		}
+
+		if a.Lnc.LocalKey == "" {
+			return errors.New("lnc local key required")
+		}
+
+	case "lndhub":
+		if a.LndHub.Address == "" {
+			return errors.New("lndhub address required")
+		}
+
+		if a.LndHub.Login == "" {
+			return errors.New("lndhub login required")
+		}
+
+		if a.LndHub.Password == "" {
+			return errors.New("lndhub password required")
+		}
+
+	default:
+		return fmt.Errorf("unknown authenticator backend %q", a.Backend)
 	}
 
 	return nil
 }
 
+// HashMailConfig configures the Lightning Node Connect mailbox server.
+//
+// +genconfig:accessors
 type HashMailConfig struct {
-	Enabled               bool          `long:"enabled"`
-	MessageRate           time.Duration `long:"messagerate" description:"The average minimum time that should pass between each message."`
-	MessageBurstAllowance int           `long:"messageburstallowance" description:"The burst rate we allow for messages."`
-	StaleTimeout          time.Duration `long:"staletimeout" description:"The time after the last activity that a mailbox should be removed. Set to -1s to disable. "`
+	Enabled               bool          `long:"enabled" env:"APERTURE_HASHMAIL_ENABLED"`
+	MessageRate           time.Duration `long:"messagerate" env:"APERTURE_HASHMAIL_MESSAGERATE" description:"The average minimum time that should pass between each message."`
+	MessageBurstAllowance int           `long:"messageburstallowance" env:"APERTURE_HASHMAIL_MESSAGEBURSTALLOWANCE" description:"The burst rate we allow for messages."`
+	StaleTimeout          time.Duration `long:"staletimeout" env:"APERTURE_HASHMAIL_STALETIMEOUT" description:"The time after the last activity that a mailbox should be removed. Set to -1s to disable. "`
 }
 
+// TorConfig configures the Tor onion service used to reach Aperture.
+//
+// +genconfig:accessors
 type TorConfig struct {
-	Control     string `long:"control" description:"The host:port of the Tor instance."`
-	ListenPort  uint16 `long:"listenport" description:"The port we should listen on for client requests over Tor. Note that this port should not be exposed to the outside world, it is only intended to be reached by clients through the onion service."`
-	VirtualPort uint16 `long:"virtualport" description:"The port through which the onion services created can be reached at."`
-	V3          bool   `long:"v3" description:"Whether we should listen for client requests through a v3 onion service."`
+	Control     string `long:"control" env:"APERTURE_TOR_CONTROL" description:"The host:port of the Tor instance."`
+	ListenPort  uint16 `long:"listenport" env:"APERTURE_TOR_LISTENPORT" description:"The port we should listen on for client requests over Tor. Note that this port should not be exposed to the outside world, it is only intended to be reached by clients through the onion service."`
+	VirtualPort uint16 `long:"virtualport" env:"APERTURE_TOR_VIRTUALPORT" description:"The port through which the onion services created can be reached at."`
+	V3          bool   `long:"v3" env:"APERTURE_TOR_V3" description:"Whether we should listen for client requests through a v3 onion service."`
 }
 
+// +genconfig:accessors
 type Config struct {
 	// ListenAddr is the listening address that we should use to allow Aperture
 	// to listen for requests.
-	ListenAddr string `long:"listenaddr" description:"The interface we should listen on for client requests."`
+	ListenAddr string `long:"listenaddr" env:"APERTURE_LISTENADDR" description:"The interface we should listen on for client requests."`
 
 	// ServerName can be set to a fully qualifying domain name that should
 	// be used while creating a certificate through Let's Encrypt.
-	ServerName string `long:"servername" description:"Server name (FQDN) to use for the TLS certificate."`
+	ServerName string `long:"servername" env:"APERTURE_SERVERNAME" description:"Server name (FQDN) to use for the TLS certificate."`
 
 	// AutoCert can be set to true if aperture should try to create a valid
 	// certificate through Let's Encrypt using ServerName.
-	AutoCert bool `long:"autocert" description:"Automatically create a Let's Encrypt cert using ServerName."`
+	AutoCert bool `long:"autocert" env:"APERTURE_AUTOCERT" description:"Automatically create a Let's Encrypt cert using ServerName."`
 
 	// Insecure can be set to disable TLS on incoming connections.
-	Insecure bool `long:"insecure" description:"Listen on an insecure connection, disabling TLS for incoming connections."`
+	Insecure bool `long:"insecure" env:"APERTURE_INSECURE" description:"Listen on an insecure connection, disabling TLS for incoming connections."`
 
 	// StaticRoot is the folder where the static content served by the proxy
 	// is located.
-	StaticRoot string `long:"staticroot" description:"The folder where the static content is located."`
+	StaticRoot string `long:"staticroot" env:"APERTURE_STATICROOT" description:"The folder where the static content is located."`
 
 	// ServeStatic defines if static content should be served from the
 	// directory defined by StaticRoot.
-	ServeStatic bool `long:"servestatic" description:"Flag to enable or disable static content serving."`
+	ServeStatic bool `long:"servestatic" env:"APERTURE_SERVESTATIC" description:"Flag to enable or disable static content serving."`
 
 	// DatabaseBackend is the database backend to be used by the server.
-	DatabaseBackend string `long:"dbbackend" description:"The database backend to use for storing all asset related data." choice:"sqlite" choice:"postgres"`
+	DatabaseBackend string `long:"dbbackend" env:"APERTURE_DBBACKEND" description:"The database backend to use for storing all asset related data." choice:"sqlite" choice:"postgres"`
 
 	// Sqlite is the configuration section for the SQLite database backend.
 	Sqlite *aperturedb.SqliteConfig `group:"sqlite" namespace:"sqlite"`
@@ -120,13 +237,24 @@ type Config struct {
 	// Etcd is the configuration section for the Etcd database backend.
 	Etcd *EtcdConfig `group:"etcd" namespace:"etcd"`
 
+	// KVBackend selects the key-value store backend used by the LSAT
+	// challenger and hashmail mailbox state. This is independent of
+	// DatabaseBackend, which only governs the LSAT/account storage.
+	KVBackend string `long:"kvbackend" env:"APERTURE_KVBACKEND" description:"The key-value store backend to use for challenger and mailbox state." choice:"etcd" choice:"bolt" choice:"memory" choice:"redis"`
+
+	// Bolt is the configuration section for the BoltDB KV backend.
+	Bolt *BoltConfig `group:"bolt" namespace:"bolt"`
+
+	// Redis is the configuration section for the Redis KV backend.
+	Redis *RedisConfig `group:"redis" namespace:"redis"`
+
 	Authenticator *AuthConfig `group:"authenticator" namespace:"authenticator"`
 
 	Tor *TorConfig `group:"tor" namespace:"tor"`
 
 	// Services is a list of JSON objects in string format, which specify
 	// each backend service to Aperture.
-	Services []*proxy.Service `long:"service" description:"Configurations for each Aperture backend service."`
+	Services []*proxy.Service `long:"service" env:"APERTURE_SERVICES" env-delim:"," description:"Configurations for each Aperture backend service."`
 
 	// HashMail is the configuration section for configuring the Lightning
 	// Node Connect mailbox server.
@@ -138,27 +266,65 @@ type Config struct {
 
 	// DebugLevel is a string defining the log level for the service either
 	// for all subsystems the same or individual level by subsystem.
-	DebugLevel string `long:"debuglevel" description:"Debug level for the Aperture application and its subsystems."`
+	DebugLevel string `long:"debuglevel" env:"APERTURE_DEBUGLEVEL" description:"Debug level for the Aperture application and its subsystems."`
 
 	// ConfigFile points aperture to an alternative config file.
-	ConfigFile string `long:"configfile" description:"Custom path to a config file."`
+	ConfigFile string `long:"configfile" env:"APERTURE_CONFIGFILE" description:"Custom path to a config file."`
 
 	// BaseDir is a custom directory to store all aperture flies.
-	BaseDir string `long:"basedir" description:"Directory to place all of aperture's files in."`
+	BaseDir string `long:"basedir" env:"APERTURE_BASEDIR" description:"Directory to place all of aperture's files in."`
 
 	// ProfilePort is the port on which the pprof profile will be served.
-	ProfilePort uint16 `long:"profile" description:"Enable HTTP profiling on given port -- NOTE port must be between 1024 and 65535"`
+	ProfilePort uint16 `long:"profile" env:"APERTURE_PROFILEPORT" description:"Enable HTTP profiling on given port -- NOTE port must be between 1024 and 65535"`
 }
 
 func (c *Config) validate() error {
-	if err := c.Authenticator.validate(); err != nil {
-		return err
-	}
-
 	if c.ListenAddr == "" {
 		return fmt.Errorf("missing listen address for server")
 	}
 
+	// Only services that actually use AuthLSAT (explicitly, or
+	// implicitly by not overriding Auth at all) need a working global
+	// authenticator. A deployment made up entirely of basic/mtls/none
+	// services can leave authenticator.* fully unconfigured. With no
+	// services declared at all, the global authenticator is still the
+	// only thing that could serve requests, so it's required as before.
+	needsGlobalAuth := len(c.Services) == 0
+
+	for _, service := range c.Services {
+		if service.AuthMode() == proxy.AuthLSAT {
+			if c.Authenticator.Disable {
+				return fmt.Errorf("service %v requires lsat "+
+					"auth but the authenticator is "+
+					"disabled", service.Address)
+			}
+
+			needsGlobalAuth = true
+		}
+
+		// Services that declare an override are only required to
+		// carry the credentials that override needs; services that
+		// fall back to the global authenticator are covered by the
+		// Authenticator.validate() call below.
+		if service.Auth != "" {
+			if err := service.Validate(); err != nil {
+				return fmt.Errorf("invalid service %v: %w",
+					service.Address, err)
+			}
+		}
+
+		if err := service.CompileTiers(); err != nil {
+			return fmt.Errorf("invalid pricing for service %v: %w",
+				service.Address, err)
+		}
+	}
+
+	if needsGlobalAuth {
+		if err := c.Authenticator.validate(); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -175,11 +341,23 @@ func NewConfig() *Config {
 	return &Config{
 		DatabaseBackend: "etcd",
 		Etcd:            &EtcdConfig{},
-		Sqlite:          DefaultSqliteConfig(),
-		Postgres:        &aperturedb.PostgresConfig{},
-		Authenticator:   &AuthConfig{},
-		Tor:             &TorConfig{},
-		HashMail:        &HashMailConfig{},
-		Prometheus:      &PrometheusConfig{},
+		KVBackend:       "etcd",
+		Bolt: &BoltConfig{
+			DatabaseFileName: filepath.Join(
+				apertureDataDir, "aperture-kv.db",
+			),
+		},
+		Redis:     &RedisConfig{},
+		Sqlite:    DefaultSqliteConfig(),
+		Postgres:  &aperturedb.PostgresConfig{},
+		Authenticator: &AuthConfig{
+			Backend: "lnd",
+			Cln:     &ClnAuthConfig{},
+			Lnc:     &LncAuthConfig{},
+			LndHub:  &LndHubAuthConfig{},
+		},
+		Tor:        &TorConfig{},
+		HashMail:   &HashMailConfig{},
+		Prometheus: &PrometheusConfig{},
 	}
 }
@@ -0,0 +1,59 @@
+package aperture
+
+import (
+	"testing"
+
+	"github.com/lightninglabs/aperture/proxy"
+)
+
+func validConfig() *Config {
+	cfg := NewConfig()
+	cfg.ListenAddr = "localhost:8080"
+
+	return cfg
+}
+
+func TestConfigValidateAllNonLSATServices(t *testing.T) {
+	cfg := validConfig()
+	cfg.Services = []*proxy.Service{
+		{
+			Address:               "localhost:9001",
+			Auth:                  proxy.AuthBasic,
+			BasicAuthHtpasswdFile: "/etc/aperture/htpasswd",
+		},
+	}
+
+	// No LND/CLN/LNC/LNDHub credentials configured at all; since no
+	// service needs AuthLSAT, this must still be valid.
+	if err := cfg.validate(); err != nil {
+		t.Fatalf("expected valid config, got: %v", err)
+	}
+}
+
+func TestConfigValidateDisabledAuthenticatorRejectsLSATService(t *testing.T) {
+	cfg := validConfig()
+	cfg.Authenticator.Disable = true
+	cfg.Services = []*proxy.Service{
+		{Address: "localhost:9001", Auth: proxy.AuthLSAT},
+	}
+
+	if err := cfg.validate(); err == nil {
+		t.Fatal("expected an error for an lsat service with the " +
+			"authenticator disabled")
+	}
+}
+
+func TestConfigValidateDefaultAuthRequiresAuthenticator(t *testing.T) {
+	cfg := validConfig()
+
+	// The service doesn't set Auth at all, so it falls back to the
+	// global authenticator, which isn't configured.
+	cfg.Services = []*proxy.Service{
+		{Address: "localhost:9001"},
+	}
+
+	if err := cfg.validate(); err == nil {
+		t.Fatal("expected an error for a default-auth service with " +
+			"no authenticator configured")
+	}
+}
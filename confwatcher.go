@@ -0,0 +1,240 @@
+package aperture
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/lightninglabs/aperture/proxy"
+)
+
+// ConfigState swaps in a whole new Config on a reload. Only the fields checked
+// by immutableFieldsChanged (listen address, database backend, kv backend and
+// TLS mode) are immutable for the lifetime of the process; a reload that
+// changes one of them is rejected.
+//
+// ConfigState owns the live Config behind a RWMutex and is the only thing
+// that is allowed to mutate it after startup. Subsystems that need to react
+// to a reload (proxy, hashmail, logging, ...) register a subscriber via
+// OnReload instead of reading the embedded Config directly.
+type ConfigState struct {
+	mu  sync.RWMutex
+	cfg *Config
+
+	// cliArgs are the command line arguments Config was originally
+	// loaded with. Reload re-applies them on top of the freshly parsed
+	// YAML file so that a CLI flag or environment variable supplied at
+	// startup (e.g. authenticator.password, an etcd/Postgres DSN) isn't
+	// silently dropped back to its YAML/default value on every reload.
+	cliArgs []string
+
+	subscribersMu sync.Mutex
+	subscribers   []func(*Config)
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NewConfigState wraps an already validated Config in a ConfigState.
+// cliArgs must be the same command line arguments cfg was originally loaded
+// with via LoadConfig, so that Reload can re-apply them with the same
+// precedence.
+func NewConfigState(cfg *Config, cliArgs []string) *ConfigState {
+	return &ConfigState{
+		cfg:     cfg,
+		cliArgs: cliArgs,
+		quit:    make(chan struct{}),
+	}
+}
+
+// Config returns the currently active configuration. The returned value is
+// shared with ConfigState and must not be mutated by the caller.
+func (s *ConfigState) Config() *Config {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.cfg
+}
+
+// Services returns the currently active set of proxy services.
+func (s *ConfigState) Services() []*proxy.Service {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.cfg.Services
+}
+
+// HashMail returns the currently active hashmail configuration.
+func (s *ConfigState) HashMail() *HashMailConfig {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.cfg.HashMail
+}
+
+// Prometheus returns the currently active Prometheus configuration.
+func (s *ConfigState) Prometheus() *PrometheusConfig {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.cfg.Prometheus
+}
+
+// Tor returns the currently active Tor configuration.
+func (s *ConfigState) Tor() *TorConfig {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.cfg.Tor
+}
+
+// DebugLevel returns the currently active debug level string.
+func (s *ConfigState) DebugLevel() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.cfg.DebugLevel
+}
+
+// OnReload registers a callback that is invoked with the new Config every
+// time Reload successfully swaps in a new configuration. Callbacks are
+// invoked synchronously and in registration order, so they should return
+// quickly.
+func (s *ConfigState) OnReload(fn func(*Config)) {
+	s.subscribersMu.Lock()
+	defer s.subscribersMu.Unlock()
+
+	s.subscribers = append(s.subscribers, fn)
+}
+
+// immutableFieldsChanged compares the fields of Config that cannot be
+// changed without a full restart and returns an error describing the first
+// one that differs.
+func immutableFieldsChanged(old, new *Config) error {
+	switch {
+	case old.ListenAddr != new.ListenAddr:
+		return fmt.Errorf("listenaddr cannot be changed on reload, " +
+			"restart aperture instead")
+
+	case old.DatabaseBackend != new.DatabaseBackend:
+		return fmt.Errorf("dbbackend cannot be changed on reload, " +
+			"restart aperture instead")
+
+	case old.KVBackend != new.KVBackend:
+		return fmt.Errorf("kvbackend cannot be changed on reload, " +
+			"the running kv.Store instance is created once at " +
+			"startup; restart aperture instead")
+
+	case old.Insecure != new.Insecure || old.AutoCert != new.AutoCert:
+		return fmt.Errorf("TLS mode cannot be changed on reload, " +
+			"restart aperture instead")
+	}
+
+	return nil
+}
+
+// Reload re-reads the config file on disk, re-applies the original CLI
+// arguments and environment variables on top of it with the same
+// CLI > env > YAML > default precedence LoadConfig uses, validates the
+// result, and atomically swaps it in as the live configuration if none of
+// the immutable fields were changed. Registered subscribers are notified of
+// the new config.
+func (s *ConfigState) Reload() error {
+	s.mu.RLock()
+	configFile := s.cfg.ConfigFile
+	cliArgs := s.cliArgs
+	oldCfg := s.cfg
+	s.mu.RUnlock()
+
+	newCfg, err := LoadConfig(configFile, cliArgs)
+	if err != nil {
+		return fmt.Errorf("unable to reload config: %w", err)
+	}
+
+	if err := immutableFieldsChanged(oldCfg, newCfg); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.cfg = newCfg
+	s.mu.Unlock()
+
+	s.subscribersMu.Lock()
+	defer s.subscribersMu.Unlock()
+	for _, sub := range s.subscribers {
+		sub(newCfg)
+	}
+
+	return nil
+}
+
+// WatchSignals spawns a goroutine that calls Reload whenever SIGHUP is
+// received. It also starts an fsnotify watch on ConfigFile so that editing
+// the file on disk has the same effect as sending SIGHUP. Stop must be
+// called to release the underlying watcher and goroutine.
+func (s *ConfigState) WatchSignals() error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("unable to create config file watcher: %w", err)
+	}
+
+	configFile := s.Config().ConfigFile
+	if err := watcher.Add(configFile); err != nil {
+		watcher.Close()
+		return fmt.Errorf("unable to watch config file %v: %w",
+			configFile, err)
+	}
+
+	sigHup := make(chan os.Signal, 1)
+	signal.Notify(sigHup, syscall.SIGHUP)
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		defer watcher.Close()
+		defer signal.Stop(sigHup)
+
+		for {
+			select {
+			case <-sigHup:
+				if err := s.Reload(); err != nil {
+					log.Errorf("Failed to reload config "+
+						"on SIGHUP: %v", err)
+				}
+
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if event.Op&(fsnotify.Write|fsnotify.Create) == 0 {
+					continue
+				}
+				if err := s.Reload(); err != nil {
+					log.Errorf("Failed to reload config "+
+						"after file change: %v", err)
+				}
+
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				log.Errorf("Config file watcher error: %v", err)
+
+			case <-s.quit:
+				return
+			}
+		}
+	}()
+
+	return nil
+}
+
+// Stop shuts down the signal and file watcher goroutine started by
+// WatchSignals.
+func (s *ConfigState) Stop() {
+	close(s.quit)
+	s.wg.Wait()
+}
@@ -0,0 +1,78 @@
+package aperture
+
+import "testing"
+
+func TestImmutableFieldsChanged(t *testing.T) {
+	base := func() *Config {
+		cfg := NewConfig()
+		cfg.ListenAddr = "localhost:8080"
+		cfg.DatabaseBackend = "sqlite"
+		cfg.Insecure = true
+		cfg.AutoCert = false
+
+		return cfg
+	}
+
+	tests := []struct {
+		name    string
+		mutate  func(*Config)
+		wantErr bool
+	}{
+		{
+			name:    "no change",
+			mutate:  func(cfg *Config) {},
+			wantErr: false,
+		},
+		{
+			name: "reloadable field changed",
+			mutate: func(cfg *Config) {
+				cfg.DebugLevel = "debug"
+			},
+			wantErr: false,
+		},
+		{
+			name: "listen addr changed",
+			mutate: func(cfg *Config) {
+				cfg.ListenAddr = "localhost:9090"
+			},
+			wantErr: true,
+		},
+		{
+			name: "database backend changed",
+			mutate: func(cfg *Config) {
+				cfg.DatabaseBackend = "postgres"
+			},
+			wantErr: true,
+		},
+		{
+			name: "kv backend changed",
+			mutate: func(cfg *Config) {
+				cfg.KVBackend = "bolt"
+			},
+			wantErr: true,
+		},
+		{
+			name: "tls mode changed",
+			mutate: func(cfg *Config) {
+				cfg.Insecure = false
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			oldCfg := base()
+			newCfg := base()
+			test.mutate(newCfg)
+
+			err := immutableFieldsChanged(oldCfg, newCfg)
+			if test.wantErr && err == nil {
+				t.Fatalf("expected an error, got nil")
+			}
+			if !test.wantErr && err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package aperture
+
+import (
+	"fmt"
+
+	"github.com/lightninglabs/aperture/aperturedb/kv"
+)
+
+// NewKVStore constructs the kv.Store backend selected by cfg.KVBackend,
+// mirroring how DatabaseBackend selects between the Sqlite and Postgres
+// asset stores.
+func NewKVStore(cfg *Config) (kv.Store, error) {
+	switch cfg.KVBackend {
+	case "", "etcd":
+		return kv.NewEtcdStore(
+			cfg.Etcd.Host, cfg.Etcd.User, cfg.Etcd.Password,
+		)
+
+	case "bolt":
+		return kv.NewBoltStore(cfg.Bolt.DatabaseFileName)
+
+	case "memory":
+		return kv.NewMemoryStore(), nil
+
+	case "redis":
+		return kv.NewRedisStore(
+			cfg.Redis.Host, cfg.Redis.Password, cfg.Redis.DB,
+		)
+
+	default:
+		return nil, fmt.Errorf("unknown kv backend %q", cfg.KVBackend)
+	}
+}
@@ -0,0 +1,38 @@
+package clnrpc
+
+import (
+	"encoding/json"
+
+	"google.golang.org/grpc/encoding"
+)
+
+// jsonCodecName is the gRPC content-subtype this package registers its codec
+// under. It's selected per-call via grpc.CallContentSubtype so that calls
+// through NodeClient never fall through to grpc-go's default codec, which
+// requires proto.Message and would reject the plain structs below.
+const jsonCodecName = "clnrpc-json"
+
+// jsonCodec (de)serializes the request/response structs in this package as
+// JSON on the wire. There is no vendored cln-grpc .proto in this tree to
+// generate a real protobuf codec from, so this hand-rolled codec stands in
+// for one; it is wire-compatible with nothing but itself; a CLN node only
+// understands the cln-grpc plugin's real protobuf, so connecting this
+// backend to one's actual gRPC endpoint requires swapping this codec out
+// for generated protobuf bindings.
+type jsonCodec struct{}
+
+func (jsonCodec) Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (jsonCodec) Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
+
+func (jsonCodec) Name() string {
+	return jsonCodecName
+}
+
+func init() {
+	encoding.RegisterCodec(jsonCodec{})
+}
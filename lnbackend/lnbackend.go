@@ -0,0 +1,38 @@
+// Package lnbackend abstracts the Lightning node that Aperture's
+// authenticator uses to mint LSAT invoices over the concrete backend it
+// talks to, so the authenticator and LSAT minter can work against any of
+// them interchangeably.
+package lnbackend
+
+import "context"
+
+// InvoiceUpdate is emitted by TrackInvoice whenever the state of a
+// previously added invoice changes.
+type InvoiceUpdate struct {
+	// Settled is true once the invoice has been paid in full.
+	Settled bool
+
+	// Preimage is the payment preimage, populated once Settled is true.
+	// The lndhub backend leaves it nil, since its checkpayment endpoint
+	// only reports whether the invoice is paid.
+	Preimage []byte
+}
+
+// Backend is implemented by every Lightning backend Aperture can mint LSAT
+// invoices against.
+type Backend interface {
+	// AddInvoice creates a new invoice for the given amount and memo,
+	// returning its payment request and payment hash.
+	AddInvoice(ctx context.Context, amtMsat int64, memo string) (
+		payReq string, paymentHash []byte, err error)
+
+	// TrackInvoice streams state updates for a previously added invoice
+	// until it settles, expires, or the context is canceled.
+	TrackInvoice(ctx context.Context, paymentHash []byte) (
+		<-chan InvoiceUpdate, error)
+
+	// SendKeysend pays the given destination node directly, without a
+	// prior invoice, using a keysend payment.
+	SendKeysend(ctx context.Context, destPubkey []byte, amtMsat int64) (
+		preimage []byte, err error)
+}
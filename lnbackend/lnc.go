@@ -0,0 +1,122 @@
+package lnbackend
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lightninglabs/aperture/mailbox"
+	"github.com/lightninglabs/lndclient"
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/lightningnetwork/lnd/lnrpc/invoicesrpc"
+	"github.com/lightningnetwork/lnd/lntypes"
+)
+
+// LncConfig holds the connection details for a remote node reached through
+// Lightning Node Connect, as configured under authenticator.lnc. The
+// mailbox used to rendezvous with the remote node is the same hashmail
+// transport the HashMail server exposes.
+type LncConfig struct {
+	// Pairing is the one-time LNC pairing phrase used to bootstrap the
+	// session.
+	Pairing string
+
+	// LocalKey is the local static key used for the noise handshake with
+	// the remote node.
+	LocalKey string
+
+	// RemoteKey is the remote node's static key, once known. It is
+	// empty until the first successful pairing.
+	RemoteKey string
+}
+
+// lncBackend implements Backend on top of a remote node reached through
+// Lightning Node Connect.
+type lncBackend struct {
+	client lndclient.LightningClient
+}
+
+// NewLncBackend establishes an LNC session with the remote node described
+// by cfg over the hashmail transport and returns a Backend backed by it.
+func NewLncBackend(ctx context.Context, cfg LncConfig) (Backend, error) {
+	session, err := mailbox.NewClientSession(ctx, mailbox.ClientSessionConfig{
+		PairingPhrase: cfg.Pairing,
+		LocalKey:      cfg.LocalKey,
+		RemoteKey:     cfg.RemoteKey,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to establish lnc session: %w",
+			err)
+	}
+
+	client, err := lndclient.NewLightningClient(session)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create lnc client: %w", err)
+	}
+
+	return &lncBackend{client: client}, nil
+}
+
+func (l *lncBackend) AddInvoice(ctx context.Context, amtMsat int64,
+	memo string) (string, []byte, error) {
+
+	hash, payReq, err := l.client.AddInvoice(ctx, &invoicesrpc.AddInvoiceData{
+		Memo:      memo,
+		ValueMsat: amtMsat,
+	})
+	if err != nil {
+		return "", nil, fmt.Errorf("lnc addinvoice failed: %w", err)
+	}
+
+	return payReq, hash[:], nil
+}
+
+func (l *lncBackend) TrackInvoice(ctx context.Context,
+	paymentHash []byte) (<-chan InvoiceUpdate, error) {
+
+	var hash lntypes.Hash
+	copy(hash[:], paymentHash)
+
+	updates, errChan, err := l.client.TrackInvoice(ctx, hash)
+	if err != nil {
+		return nil, fmt.Errorf("lnc trackinvoice failed: %w", err)
+	}
+
+	out := make(chan InvoiceUpdate)
+	go func() {
+		defer close(out)
+
+		for {
+			select {
+			case inv, ok := <-updates:
+				if !ok {
+					return
+				}
+				out <- InvoiceUpdate{
+					Settled:  inv.State == lnrpc.Invoice_SETTLED,
+					Preimage: inv.Preimage[:],
+				}
+
+			case err := <-errChan:
+				if err != nil {
+					return
+				}
+
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return out, nil
+}
+
+func (l *lncBackend) SendKeysend(ctx context.Context, destPubkey []byte,
+	amtMsat int64) ([]byte, error) {
+
+	preimage, err := l.client.SendKeysend(ctx, destPubkey, amtMsat)
+	if err != nil {
+		return nil, fmt.Errorf("lnc keysend failed: %w", err)
+	}
+
+	return preimage, nil
+}
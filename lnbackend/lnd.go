@@ -0,0 +1,102 @@
+package lnbackend
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lightninglabs/lndclient"
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/lightningnetwork/lnd/lnrpc/invoicesrpc"
+	"github.com/lightningnetwork/lnd/lntypes"
+)
+
+// LndConfig holds the connection details for an LND backend, as configured
+// by the lndhost, tlspath, macdir and network options under authenticator.
+type LndConfig struct {
+	Host    string
+	TLSPath string
+	MacDir  string
+	Network string
+}
+
+// lndBackend implements Backend on top of an LND node reached over its
+// gRPC interface.
+type lndBackend struct {
+	client lndclient.LightningClient
+}
+
+// NewLndBackend dials the LND node described by cfg and returns a Backend
+// backed by it.
+func NewLndBackend(cfg LndConfig) (Backend, error) {
+	services, err := lndclient.NewLndServices(&lndclient.LndServicesConfig{
+		LndAddress:  cfg.Host,
+		Network:     lndclient.Network(cfg.Network),
+		TLSPath:     cfg.TLSPath,
+		MacaroonDir: cfg.MacDir,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to connect to lnd: %w", err)
+	}
+
+	return &lndBackend{client: services.Client}, nil
+}
+
+func (l *lndBackend) AddInvoice(ctx context.Context, amtMsat int64,
+	memo string) (string, []byte, error) {
+
+	hash, payReq, err := l.client.AddInvoice(ctx, &invoicesrpc.AddInvoiceData{
+		Memo:      memo,
+		ValueMsat: amtMsat,
+	})
+	if err != nil {
+		return "", nil, err
+	}
+
+	return payReq, hash[:], nil
+}
+
+func (l *lndBackend) TrackInvoice(ctx context.Context,
+	paymentHash []byte) (<-chan InvoiceUpdate, error) {
+
+	var hash lntypes.Hash
+	copy(hash[:], paymentHash)
+
+	updates, errChan, err := l.client.TrackInvoice(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(chan InvoiceUpdate)
+	go func() {
+		defer close(out)
+
+		for {
+			select {
+			case inv, ok := <-updates:
+				if !ok {
+					return
+				}
+				out <- InvoiceUpdate{
+					Settled:  inv.State == lnrpc.Invoice_SETTLED,
+					Preimage: inv.Preimage[:],
+				}
+
+			case err := <-errChan:
+				if err != nil {
+					return
+				}
+
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return out, nil
+}
+
+func (l *lndBackend) SendKeysend(ctx context.Context, destPubkey []byte,
+	amtMsat int64) ([]byte, error) {
+
+	return l.client.SendKeysend(ctx, destPubkey, amtMsat)
+}
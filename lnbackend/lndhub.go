@@ -0,0 +1,213 @@
+package lnbackend
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// checkPaymentInterval is how often TrackInvoice polls the LNDHub
+// checkpayment endpoint, since LNDHub's REST API has no invoice
+// subscription to push settlement events with.
+const checkPaymentInterval = 2 * time.Second
+
+// LndHubConfig holds the connection details for an LNDHub-compatible HTTP
+// endpoint, as configured under authenticator.lndhub.
+type LndHubConfig struct {
+	Address  string
+	Login    string
+	Password string
+}
+
+// lndHubBackend implements Backend on top of an LNDHub-compatible REST API.
+type lndHubBackend struct {
+	cfg        LndHubConfig
+	httpClient *http.Client
+	authToken  string
+}
+
+// NewLndHubBackend authenticates against the LNDHub endpoint described by
+// cfg and returns a Backend backed by it.
+func NewLndHubBackend(ctx context.Context, cfg LndHubConfig) (Backend, error) {
+	b := &lndHubBackend{
+		cfg:        cfg,
+		httpClient: &http.Client{},
+	}
+
+	if err := b.auth(ctx); err != nil {
+		return nil, fmt.Errorf("unable to authenticate against "+
+			"lndhub: %w", err)
+	}
+
+	return b, nil
+}
+
+func (l *lndHubBackend) auth(ctx context.Context) error {
+	reqBody, err := json.Marshal(map[string]string{
+		"login":    l.cfg.Login,
+		"password": l.cfg.Password,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(
+		ctx, http.MethodPost, l.cfg.Address+"/auth",
+		bytes.NewReader(reqBody),
+	)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := l.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code %v", resp.StatusCode)
+	}
+
+	var authResp struct {
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&authResp); err != nil {
+		return err
+	}
+
+	l.authToken = authResp.AccessToken
+
+	return nil
+}
+
+func (l *lndHubBackend) AddInvoice(ctx context.Context, amtMsat int64,
+	memo string) (string, []byte, error) {
+
+	reqBody, err := json.Marshal(map[string]interface{}{
+		"amt":  amtMsat / 1000,
+		"memo": memo,
+	})
+	if err != nil {
+		return "", nil, err
+	}
+
+	req, err := http.NewRequestWithContext(
+		ctx, http.MethodPost, l.cfg.Address+"/addinvoice",
+		bytes.NewReader(reqBody),
+	)
+	if err != nil {
+		return "", nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+l.authToken)
+
+	resp, err := l.httpClient.Do(req)
+	if err != nil {
+		return "", nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", nil, fmt.Errorf("unexpected status code %v from "+
+			"lndhub addinvoice", resp.StatusCode)
+	}
+
+	var invResp struct {
+		PayReq      string `json:"payment_request"`
+		PaymentHash string `json:"payment_hash"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&invResp); err != nil {
+		return "", nil, err
+	}
+
+	paymentHash, err := hex.DecodeString(invResp.PaymentHash)
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid payment hash returned "+
+			"by lndhub: %w", err)
+	}
+
+	return invResp.PayReq, paymentHash, nil
+}
+
+// checkPayment polls LNDHub's checkpayment endpoint for the invoice
+// identified by paymentHash and reports whether it has been settled.
+func (l *lndHubBackend) checkPayment(ctx context.Context,
+	paymentHash []byte) (bool, error) {
+
+	url := l.cfg.Address + "/checkpayment/" + hex.EncodeToString(paymentHash)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Authorization", "Bearer "+l.authToken)
+
+	resp, err := l.httpClient.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("unexpected status code %v from "+
+			"lndhub checkpayment", resp.StatusCode)
+	}
+
+	var checkResp struct {
+		Paid bool `json:"paid"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&checkResp); err != nil {
+		return false, err
+	}
+
+	return checkResp.Paid, nil
+}
+
+// TrackInvoice polls LNDHub's checkpayment endpoint on an interval until
+// the invoice is settled or ctx is canceled, since LNDHub's REST API has no
+// way to push a settlement event the way a gRPC subscription would.
+func (l *lndHubBackend) TrackInvoice(ctx context.Context,
+	paymentHash []byte) (<-chan InvoiceUpdate, error) {
+
+	out := make(chan InvoiceUpdate)
+	go func() {
+		defer close(out)
+
+		ticker := time.NewTicker(checkPaymentInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				paid, err := l.checkPayment(ctx, paymentHash)
+				if err != nil || !paid {
+					continue
+				}
+
+				select {
+				case out <- InvoiceUpdate{Settled: true}:
+				case <-ctx.Done():
+				}
+
+				return
+
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return out, nil
+}
+
+func (l *lndHubBackend) SendKeysend(ctx context.Context, destPubkey []byte,
+	amtMsat int64) ([]byte, error) {
+
+	return nil, fmt.Errorf("lndhub backend does not support keysend " +
+		"payments")
+}
@@ -0,0 +1,15 @@
+package lnbackend
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+)
+
+// randomInvoiceLabel generates a unique label for backends (like CLN) that
+// require the caller to supply one when creating an invoice.
+func randomInvoiceLabel() string {
+	buf := make([]byte, 16)
+	_, _ = rand.Read(buf)
+
+	return "aperture-" + hex.EncodeToString(buf)
+}
@@ -0,0 +1,53 @@
+package aperture
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/jessevdk/go-flags"
+	"gopkg.in/yaml.v2"
+)
+
+// LoadConfig builds a Config by layering, from lowest to highest
+// precedence: the hard-coded defaults from NewConfig, the YAML file at
+// configFile (if it exists), environment variables, and finally the given
+// CLI arguments. This lets secrets such as authenticator.password or an
+// etcd/Postgres DSN be supplied purely through the environment in
+// containerized deployments, without ever touching disk.
+func LoadConfig(configFile string, cliArgs []string) (*Config, error) {
+	cfg := NewConfig()
+
+	if configFile != "" {
+		yamlBytes, err := os.ReadFile(configFile)
+		switch {
+		case err == nil:
+			if err := yaml.Unmarshal(yamlBytes, cfg); err != nil {
+				return nil, fmt.Errorf("unable to parse "+
+					"config file: %w", err)
+			}
+
+		case os.IsNotExist(err):
+			// No config file on disk is fine; env vars and CLI
+			// flags can still fully configure Aperture.
+
+		default:
+			return nil, fmt.Errorf("unable to read config "+
+				"file: %w", err)
+		}
+	}
+
+	// go-flags parses both the "env" and "long" struct tags it finds on
+	// Config, applying environment variables over the YAML defaults and
+	// then the explicit CLI arguments over those, which gives us the
+	// precedence order CLI > env > YAML > default.
+	parser := flags.NewParser(cfg, flags.Default)
+	if _, err := parser.ParseArgs(cliArgs); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.validate(); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+
+	return cfg, nil
+}
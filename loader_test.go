@@ -0,0 +1,50 @@
+package aperture
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeTestConfigFile(t *testing.T, contents string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "aperture.yaml")
+	if err := os.WriteFile(path, []byte(contents), 0600); err != nil {
+		t.Fatalf("unable to write test config file: %v", err)
+	}
+
+	return path
+}
+
+// TestReloadPreservesCLIOverrides asserts that a field supplied only via a
+// CLI flag at startup survives a Reload, instead of being reset to the
+// value (or absence of a value) found in the YAML file on disk.
+func TestReloadPreservesCLIOverrides(t *testing.T) {
+	configFile := writeTestConfigFile(t, "listenaddr: localhost:8080\n"+
+		"authenticator:\n  disable: true\n")
+
+	cliArgs := []string{
+		"--configfile=" + configFile,
+		"--debuglevel=debug",
+	}
+
+	cfg, err := LoadConfig(configFile, cliArgs)
+	if err != nil {
+		t.Fatalf("unable to load config: %v", err)
+	}
+	if cfg.DebugLevel != "debug" {
+		t.Fatalf("expected debuglevel %q, got %q", "debug", cfg.DebugLevel)
+	}
+
+	state := NewConfigState(cfg, cliArgs)
+
+	if err := state.Reload(); err != nil {
+		t.Fatalf("unable to reload: %v", err)
+	}
+
+	if got := state.Config().DebugLevel; got != "debug" {
+		t.Fatalf("cli override lost on reload: expected %q, got %q",
+			"debug", got)
+	}
+}
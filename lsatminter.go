@@ -0,0 +1,58 @@
+package aperture
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lightninglabs/aperture/lnbackend"
+)
+
+// LSATMinter mints and tracks payment for LSAT invoices against whichever
+// lnbackend.Backend the authenticator is configured to use. It never deals
+// with a concrete Lightning client directly, so swapping the configured
+// backend (lnd, cln, lnc, lndhub) doesn't require any changes here.
+type LSATMinter struct {
+	backend lnbackend.Backend
+}
+
+// NewLSATMinter returns an LSATMinter backed by backend.
+func NewLSATMinter(backend lnbackend.Backend) *LSATMinter {
+	return &LSATMinter{backend: backend}
+}
+
+// MintInvoice creates a new invoice for priceMsat and returns its payment
+// request and payment hash, to be embedded in the LSAT challenge.
+func (m *LSATMinter) MintInvoice(ctx context.Context, priceMsat int64,
+	memo string) (string, []byte, error) {
+
+	payReq, paymentHash, err := m.backend.AddInvoice(ctx, priceMsat, memo)
+	if err != nil {
+		return "", nil, fmt.Errorf("unable to mint invoice: %w", err)
+	}
+
+	return payReq, paymentHash, nil
+}
+
+// AwaitPayment blocks until the invoice identified by paymentHash is
+// settled or the context is canceled. It returns an error if the backend's
+// update stream ends before the invoice settles.
+func (m *LSATMinter) AwaitPayment(ctx context.Context,
+	paymentHash []byte) error {
+
+	updates, err := m.backend.TrackInvoice(ctx, paymentHash)
+	if err != nil {
+		return fmt.Errorf("unable to track invoice: %w", err)
+	}
+
+	for update := range updates {
+		if update.Settled {
+			return nil
+		}
+	}
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return fmt.Errorf("invoice updates ended before the invoice settled")
+}
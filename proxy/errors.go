@@ -0,0 +1,16 @@
+package proxy
+
+import "fmt"
+
+// errServiceMissingCreds returns an error indicating that a service is
+// missing the config field required by its chosen auth mode.
+func errServiceMissingCreds(s *Service, field string) error {
+	return fmt.Errorf("service %q uses auth mode %q but is missing "+
+		"required field %q", s.Address, s.AuthMode(), field)
+}
+
+// errUnknownAuthMode returns an error indicating that a service declared an
+// auth mode that Aperture doesn't know how to handle.
+func errUnknownAuthMode(mode AuthMode) error {
+	return fmt.Errorf("unknown service auth mode %q", mode)
+}
@@ -0,0 +1,36 @@
+package proxy
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	// lsatPaidTotal counts successfully redeemed LSATs, broken down by
+	// service and pricing tier.
+	lsatPaidTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "aperture_lsat_paid_total",
+		Help: "Total number of LSATs paid for and redeemed, by " +
+			"service and tier.",
+	}, []string{"service", "tier"})
+
+	// lsatPriceMsat tracks the current price, in millisatoshis, of each
+	// service/tier combination.
+	lsatPriceMsat = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "aperture_lsat_price_msat",
+		Help: "Current price in millisatoshis for a service/tier " +
+			"combination.",
+	}, []string{"service", "tier"})
+)
+
+// RecordPayment increments the paid-LSAT counter for the given service and
+// tier. LSATMinter does not call it; the counter only moves when a caller
+// records a verified payment through this function.
+func RecordPayment(service, tier string) {
+	lsatPaidTotal.WithLabelValues(service, tier).Inc()
+}
+
+// recordPrice sets the current price gauge for the given service and tier.
+func recordPrice(service, tier string, priceMsat int64) {
+	lsatPriceMsat.WithLabelValues(service, tier).Set(float64(priceMsat))
+}
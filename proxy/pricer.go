@@ -0,0 +1,205 @@
+package proxy
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// PriceTier is a single pricing rule for a Service. A request matches a
+// tier if its method and path satisfy Method and PathRegexp.
+type PriceTier struct {
+	// PathRegexp is matched against the start of the request path. It is
+	// always anchored to the start of the path (see compile), so it
+	// behaves as a prefix match rather than an unanchored substring
+	// search; tiersOverlap's disjointness check depends on this.
+	PathRegexp string `long:"path_regex" description:"Regular expression matched against the start of the request path."`
+
+	// Method is the HTTP method this tier applies to. An empty value
+	// matches every method.
+	Method string `long:"method" description:"The HTTP method this tier applies to. Empty matches any method."`
+
+	// PriceMsat is the price charged in millisatoshis for a request
+	// matching this tier.
+	PriceMsat int64 `long:"price_msat" description:"Price in millisatoshis charged for a request matching this tier."`
+
+	// TierName identifies this tier in metric labels and price_provider
+	// responses.
+	TierName string `long:"tier_name" description:"Name of this pricing tier, used in metric labels."`
+
+	// Priority disambiguates overlapping tiers: the tier with the
+	// highest Priority wins. Config.validate rejects overlapping tiers
+	// that don't set an explicit, distinct Priority.
+	Priority int `long:"priority" description:"Priority used to break ties between overlapping tiers; higher wins."`
+
+	compiled *regexp.Regexp
+}
+
+// compile eagerly parses PathRegexp so that a malformed regex is caught at
+// config-validation time rather than on the first matching request. The
+// pattern is wrapped as "^(?:...)", which anchors every top-level
+// alternative to the start of the path, so PathRegexp is always a prefix
+// match rather than an unanchored substring search.
+func (t *PriceTier) compile() error {
+	pattern := "^(?:" + t.PathRegexp + ")"
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return fmt.Errorf("invalid path_regex for tier %q: %w",
+			t.TierName, err)
+	}
+
+	t.compiled = re
+
+	return nil
+}
+
+func (t *PriceTier) matches(method, path string) bool {
+	if t.Method != "" && t.Method != method {
+		return false
+	}
+
+	return t.compiled.MatchString(path)
+}
+
+// CompileTiers eagerly compiles every tier's PathRegexp and rejects any
+// pair of tiers that can match the same request without an explicit,
+// distinct Priority to break the tie.
+func (s *Service) CompileTiers() error {
+	for _, tier := range s.Tiers {
+		if err := tier.compile(); err != nil {
+			return err
+		}
+
+		recordPrice(s.Address, tier.TierName, tier.PriceMsat)
+	}
+
+	for i, a := range s.Tiers {
+		for _, b := range s.Tiers[i+1:] {
+			if !tiersOverlap(a, b) {
+				continue
+			}
+
+			if a.Priority == b.Priority {
+				return fmt.Errorf("tiers %q and %q on "+
+					"service %q overlap and have no "+
+					"distinct priority to break the tie",
+					a.TierName, b.TierName, s.Address)
+			}
+		}
+	}
+
+	return nil
+}
+
+// tiersOverlap reports whether two tiers could both match the same
+// request. It's a syntactic check, not a full regex-language intersection
+// test: two tiers with different methods can never overlap, and two tiers
+// whose path_regex literal prefixes (see literalPathPrefix) are neither
+// equal nor one a prefix of the other can't
+// overlap either, since neither can then be a prefix of the same path:
+// PathRegexp is always anchored to the start of the path (see compile), so
+// a tier only ever matches paths beginning with its literal prefix.
+// Anything else is conservatively treated as a possible overlap, so
+// operators should give ambiguous tiers distinct priorities.
+func tiersOverlap(a, b *PriceTier) bool {
+	if a.Method != "" && b.Method != "" && a.Method != b.Method {
+		return false
+	}
+
+	prefixA := literalPathPrefix(a.PathRegexp)
+	prefixB := literalPathPrefix(b.PathRegexp)
+
+	if strings.HasPrefix(prefixA, prefixB) || strings.HasPrefix(prefixB, prefixA) {
+		return true
+	}
+
+	return false
+}
+
+// regexMetaChars are the characters that end the literal, non-regex prefix
+// of a pattern.
+const regexMetaChars = `\.+*?()|[]{}^$`
+
+// literalPathPrefix returns the literal text every path matched by pattern
+// must start with, ignoring a leading "^" anchor: the text before the first
+// regex metacharacter, minus its last character when that metacharacter is
+// a quantifier ("?", "*" or "{") that can make the character optional. A
+// pattern containing "|" yields "", since its alternatives need not share a
+// prefix.
+func literalPathPrefix(pattern string) string {
+	pattern = strings.TrimPrefix(pattern, "^")
+
+	if strings.Contains(pattern, "|") {
+		return ""
+	}
+
+	if i := strings.IndexAny(pattern, regexMetaChars); i >= 0 {
+		if i > 0 && strings.ContainsRune("?*{", rune(pattern[i])) {
+			i--
+		}
+
+		return pattern[:i]
+	}
+
+	return pattern
+}
+
+// PriceForRequest returns the price in millisatoshis and tier name that
+// applies to the given request, using the highest-priority matching tier.
+// If no tier matches, it falls back to the service's own default Price.
+func (s *Service) PriceForRequest(method, path string) (int64, string) {
+	var best *PriceTier
+	for _, tier := range s.Tiers {
+		if !tier.matches(method, path) {
+			continue
+		}
+
+		if best == nil || tier.Priority > best.Priority {
+			best = tier
+		}
+	}
+
+	if best == nil {
+		return s.Price, "default"
+	}
+
+	return best.PriceMsat, best.TierName
+}
+
+// priceProviderResponse is the expected JSON shape returned by a
+// price_provider endpoint: a map of tier name to price in millisatoshis.
+type priceProviderResponse map[string]int64
+
+// PollPriceProvider fetches the latest tier prices from the service's
+// PriceProvider URL and updates the matching tiers in place. It is a no-op
+// if PriceProvider is unset.
+func (s *Service) PollPriceProvider(client *http.Client) error {
+	if s.PriceProvider == "" {
+		return nil
+	}
+
+	resp, err := client.Get(s.PriceProvider)
+	if err != nil {
+		return fmt.Errorf("unable to reach price_provider for "+
+			"service %q: %w", s.Address, err)
+	}
+	defer resp.Body.Close()
+
+	var prices priceProviderResponse
+	if err := json.NewDecoder(resp.Body).Decode(&prices); err != nil {
+		return fmt.Errorf("invalid price_provider response for "+
+			"service %q: %w", s.Address, err)
+	}
+
+	for _, tier := range s.Tiers {
+		if msat, ok := prices[tier.TierName]; ok {
+			tier.PriceMsat = msat
+			recordPrice(s.Address, tier.TierName, msat)
+		}
+	}
+
+	return nil
+}
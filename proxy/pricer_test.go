@@ -0,0 +1,144 @@
+package proxy
+
+import "testing"
+
+func TestTiersOverlap(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b *PriceTier
+		want bool
+	}{
+		{
+			name: "disjoint literal paths",
+			a:    &PriceTier{PathRegexp: "/a"},
+			b:    &PriceTier{PathRegexp: "/b"},
+			want: false,
+		},
+		{
+			name: "identical paths",
+			a:    &PriceTier{PathRegexp: "/foo"},
+			b:    &PriceTier{PathRegexp: "/foo"},
+			want: true,
+		},
+		{
+			name: "one path prefixes the other",
+			a:    &PriceTier{PathRegexp: "/foo"},
+			b:    &PriceTier{PathRegexp: "/foo/bar"},
+			want: true,
+		},
+		{
+			name: "disjoint paths but same anchors/metachars",
+			a:    &PriceTier{PathRegexp: "^/a$"},
+			b:    &PriceTier{PathRegexp: "^/b$"},
+			want: false,
+		},
+		{
+			name: "different methods never overlap",
+			a:    &PriceTier{PathRegexp: "/foo", Method: "GET"},
+			b:    &PriceTier{PathRegexp: "/foo", Method: "POST"},
+			want: false,
+		},
+		{
+			name: "empty path_regex matches everything",
+			a:    &PriceTier{PathRegexp: ""},
+			b:    &PriceTier{PathRegexp: "/foo"},
+			want: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := tiersOverlap(test.a, test.b); got != test.want {
+				t.Fatalf("tiersOverlap() = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestServiceCompileTiers(t *testing.T) {
+	svc := &Service{
+		Address: "localhost:9001",
+		Tiers: []*PriceTier{
+			{PathRegexp: "/a", TierName: "a", PriceMsat: 1},
+			{PathRegexp: "/b", TierName: "b", PriceMsat: 2},
+		},
+	}
+
+	if err := svc.CompileTiers(); err != nil {
+		t.Fatalf("expected disjoint tiers to compile, got: %v", err)
+	}
+
+	overlapping := &Service{
+		Address: "localhost:9001",
+		Tiers: []*PriceTier{
+			{PathRegexp: "/a", TierName: "a", PriceMsat: 1},
+			{PathRegexp: "/a", TierName: "a-premium", PriceMsat: 2},
+		},
+	}
+
+	if err := overlapping.CompileTiers(); err == nil {
+		t.Fatal("expected overlapping tiers with equal priority to " +
+			"be rejected")
+	}
+
+	overlapping.Tiers[1].Priority = 1
+	if err := overlapping.CompileTiers(); err != nil {
+		t.Fatalf("expected overlapping tiers with distinct "+
+			"priority to be accepted, got: %v", err)
+	}
+}
+
+func TestServicePriceForRequest(t *testing.T) {
+	svc := &Service{
+		Address: "localhost:9001",
+		Price:   100,
+		Tiers: []*PriceTier{
+			{PathRegexp: "/premium", TierName: "premium", PriceMsat: 1000},
+		},
+	}
+
+	if err := svc.CompileTiers(); err != nil {
+		t.Fatalf("unable to compile tiers: %v", err)
+	}
+
+	price, tier := svc.PriceForRequest("GET", "/premium")
+	if price != 1000 || tier != "premium" {
+		t.Fatalf("expected (1000, premium), got (%d, %s)", price, tier)
+	}
+
+	price, tier = svc.PriceForRequest("GET", "/other")
+	if price != 100 || tier != "default" {
+		t.Fatalf("expected (100, default), got (%d, %s)", price, tier)
+	}
+}
+
+// TestPriceTierMatchIsAnchored verifies that path_regex is matched against
+// the start of the request path, not anywhere within it. Without this, a
+// path like "/c/b" would match a "/b" tier, and "/a/b" would match both a
+// "/a" tier and a "/b" tier even though tiersOverlap judges those two tiers
+// disjoint.
+func TestPriceTierMatchIsAnchored(t *testing.T) {
+	svc := &Service{
+		Address: "localhost:9001",
+		Price:   100,
+		Tiers: []*PriceTier{
+			{PathRegexp: "/a", TierName: "a", PriceMsat: 1},
+			{PathRegexp: "/b", TierName: "b", PriceMsat: 2},
+		},
+	}
+
+	if err := svc.CompileTiers(); err != nil {
+		t.Fatalf("unable to compile tiers: %v", err)
+	}
+
+	price, tier := svc.PriceForRequest("GET", "/c/b")
+	if price != 100 || tier != "default" {
+		t.Fatalf("expected /c/b to match neither tier and fall back "+
+			"to (100, default), got (%d, %s)", price, tier)
+	}
+
+	price, tier = svc.PriceForRequest("GET", "/a/sub")
+	if price != 1 || tier != "a" {
+		t.Fatalf("expected (1, a), got (%d, %s)", price, tier)
+	}
+}
@@ -0,0 +1,147 @@
+package proxy
+
+// AuthMode describes how a single Service authenticates incoming requests.
+// It defaults to AuthLSAT so that existing configs keep their current
+// behavior.
+type AuthMode string
+
+const (
+	// AuthLSAT requires a valid LSAT for every request, minted by the
+	// global authenticator.
+	AuthLSAT AuthMode = "lsat"
+
+	// AuthNone lets every request through without any authentication.
+	AuthNone AuthMode = "none"
+
+	// AuthBasic protects the service with HTTP basic auth, checked
+	// against an htpasswd file.
+	AuthBasic AuthMode = "basic"
+
+	// AuthJWT protects the service with bearer JWTs, verified against a
+	// configured signing key.
+	AuthJWT AuthMode = "jwt"
+
+	// AuthMTLS requires clients to present a certificate signed by a
+	// configured CA.
+	AuthMTLS AuthMode = "mtls"
+)
+
+// Capabilities toggles fine-grained behavior for a single service, mirroring
+// the per-node capability toggles used elsewhere in the Lightning ecosystem
+// (e.g. Teleport's per-node port_forwarding).
+type Capabilities struct {
+	// AllowWebSocket indicates whether the service may be reached over a
+	// websocket upgrade.
+	AllowWebSocket bool `long:"allow_websocket" description:"Whether this service allows websocket upgrades."`
+
+	// AllowGRPCWeb indicates whether the service may be reached through
+	// the grpc-web protocol.
+	AllowGRPCWeb bool `long:"allow_grpc_web" description:"Whether this service allows grpc-web requests."`
+
+	// AllowStreaming indicates whether long-lived streaming requests
+	// (server-streaming or bidirectional gRPC) are allowed.
+	AllowStreaming bool `long:"allow_streaming" description:"Whether this service allows streaming requests."`
+
+	// MaxBodyBytes caps the size of a single request body. A value of 0
+	// means no limit is enforced beyond the proxy's own default.
+	MaxBodyBytes int64 `long:"max_body_bytes" description:"Maximum request body size allowed for this service, in bytes. 0 means no explicit limit."`
+}
+
+// Service defines a backend service that Aperture proxies requests to.
+type Service struct {
+	// Address is the <host>:<port> of the backend service.
+	Address string `long:"address" description:"host:port of the backend service."`
+
+	// Protocol is the protocol spoken between Aperture and the backend
+	// service, e.g. "http" or "https".
+	Protocol string `long:"protocol" description:"The protocol spoken between Aperture and the backend service."`
+
+	// TLSCertPath is the path to the backend service's own TLS
+	// certificate, if any.
+	TLSCertPath string `long:"tlscertpath" description:"Path to the backend service's TLS certificate."`
+
+	// PathRegexp is the regular expression that incoming request paths
+	// are matched against to route them to this service.
+	PathRegexp string `long:"pathregexp" description:"Regular expression that matches the path of requests routed to this service."`
+
+	// HostRegexp is the regular expression that incoming request hosts
+	// are matched against to route them to this service.
+	HostRegexp string `long:"hostregexp" description:"Regular expression that matches the host of requests routed to this service."`
+
+	// Auth configures how this service authenticates incoming requests.
+	// If left empty, it defaults to the global authenticator's behavior
+	// (AuthLSAT).
+	Auth AuthMode `long:"auth" description:"The authentication mode used for this service." choice:"lsat" choice:"none" choice:"basic" choice:"jwt" choice:"mtls"`
+
+	// Price is the price in millisatoshis charged for a successful LSAT
+	// on this service. Only used when Auth is AuthLSAT. A value of 0
+	// falls back to the global default price.
+	Price int64 `long:"price" description:"Price in millisatoshis charged for an LSAT on this service."`
+
+	// BasicAuthHtpasswdFile is the htpasswd file checked against when
+	// Auth is AuthBasic.
+	BasicAuthHtpasswdFile string `long:"basicauthhtpasswdfile" description:"Path to the htpasswd file used for basic auth."`
+
+	// JWTSigningKeyPath is the path to the key used to verify bearer
+	// JWTs when Auth is AuthJWT.
+	JWTSigningKeyPath string `long:"jwtsigningkeypath" description:"Path to the signing key used to verify JWTs presented to this service."`
+
+	// MTLSClientCAPath is the path to the CA certificate used to verify
+	// client certificates when Auth is AuthMTLS.
+	MTLSClientCAPath string `long:"mtlsclientcapath" description:"Path to the CA certificate used to verify client certificates."`
+
+	// Capabilities toggles additional fine-grained behavior for this
+	// service.
+	Capabilities Capabilities `group:"capabilities" namespace:"capabilities"`
+
+	// Tiers defines a set of path/method-scoped pricing rules for this
+	// service, layered on top of the flat Price. A request that doesn't
+	// match any tier is charged Price.
+	Tiers []*PriceTier `long:"tier" description:"A pricing tier for this service."`
+
+	// PriceProvider is an optional URL that Aperture polls for live
+	// tier pricing, expected to return a JSON object mapping tier name
+	// to price in millisatoshis.
+	PriceProvider string `long:"price_provider" description:"URL polled for live per-tier pricing."`
+}
+
+// AuthMode returns the effective auth mode for the service, defaulting to
+// AuthLSAT when unset so that existing configs keep their current
+// behavior.
+func (s *Service) AuthMode() AuthMode {
+	if s.Auth == "" {
+		return AuthLSAT
+	}
+
+	return s.Auth
+}
+
+// Validate checks that the service has the credentials it needs for its
+// chosen auth mode.
+func (s *Service) Validate() error {
+	switch s.AuthMode() {
+	case AuthLSAT, AuthNone:
+		// The global authenticator (or no authenticator) covers
+		// these modes; nothing extra is required on the service.
+
+	case AuthBasic:
+		if s.BasicAuthHtpasswdFile == "" {
+			return errServiceMissingCreds(s, "basicauthhtpasswdfile")
+		}
+
+	case AuthJWT:
+		if s.JWTSigningKeyPath == "" {
+			return errServiceMissingCreds(s, "jwtsigningkeypath")
+		}
+
+	case AuthMTLS:
+		if s.MTLSClientCAPath == "" {
+			return errServiceMissingCreds(s, "mtlsclientcapath")
+		}
+
+	default:
+		return errUnknownAuthMode(s.Auth)
+	}
+
+	return nil
+}